@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultCacheTTL은 캐시된 PR을 네트워크 확인 없이 그대로 재사용할 수 있는 기간이다.
+// 이 시간이 지나면 If-None-Match로 조건부 요청을 보내 open/merged 같은 상태 전환을
+// 여전히 적시에 관찰한다.
+const defaultCacheTTL = 5 * time.Minute
+
+type cacheEntry struct {
+	PR        *PullRequest `json:"pr"`
+	ETag      string       `json:"etag"`
+	FetchedAt time.Time    `json:"fetched_at"`
+}
+
+type diskCacheFile struct {
+	Entries       map[int]*cacheEntry `json:"entries"`
+	ListNumbers   []int               `json:"list_numbers,omitempty"`
+	ListETag      string              `json:"list_etag,omitempty"`
+	ListFetchedAt time.Time           `json:"list_fetched_at,omitempty"`
+}
+
+// diskCache persists fetched PRs (plus their ETag) to
+// $XDG_CACHE_HOME/stacked-pr/{owner}_{repo}.json, so repeat invocations during an
+// interactive review session don't re-fetch every PR every time.
+type diskCache struct {
+	path string
+	ttl  time.Duration
+	data diskCacheFile
+}
+
+func newDiskCache(owner, repo string, ttl time.Duration) (*diskCache, error) {
+	path, err := diskCachePath(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	dc := &diskCache{
+		path: path,
+		ttl:  ttl,
+		data: diskCacheFile{Entries: make(map[int]*cacheEntry)},
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return dc, nil
+		}
+		return nil, err
+	}
+
+	// 캐시 파일이 손상됐으면 비워진 캐시로 새로 시작한다
+	if err := json.Unmarshal(raw, &dc.data); err != nil || dc.data.Entries == nil {
+		dc.data = diskCacheFile{Entries: make(map[int]*cacheEntry)}
+	}
+
+	return dc, nil
+}
+
+func diskCachePath(owner, repo string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "stacked-pr", fmt.Sprintf("%s_%s.json", owner, repo)), nil
+}
+
+func (dc *diskCache) save() error {
+	if err := os.MkdirAll(filepath.Dir(dc.path), 0o755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(dc.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dc.path, raw, 0o644)
+}
+
+// fresh returns the cached PR without touching the network, if present and within TTL.
+func (dc *diskCache) fresh(number int) *PullRequest {
+	entry, ok := dc.data.Entries[number]
+	if !ok || time.Since(entry.FetchedAt) > dc.ttl {
+		return nil
+	}
+	return entry.PR
+}
+
+// stale returns the cached PR regardless of TTL, for reuse on an HTTP 304 response.
+func (dc *diskCache) stale(number int) *PullRequest {
+	if entry, ok := dc.data.Entries[number]; ok {
+		return entry.PR
+	}
+	return nil
+}
+
+func (dc *diskCache) etag(number int) string {
+	if entry, ok := dc.data.Entries[number]; ok {
+		return entry.ETag
+	}
+	return ""
+}
+
+func (dc *diskCache) put(number int, pr *PullRequest, etag string) {
+	dc.data.Entries[number] = &cacheEntry{PR: pr, ETag: etag, FetchedAt: time.Now()}
+}
+
+func (dc *diskCache) freshList() []int {
+	if dc.data.ListNumbers == nil || time.Since(dc.data.ListFetchedAt) > dc.ttl {
+		return nil
+	}
+	return dc.data.ListNumbers
+}
+
+func (dc *diskCache) staleList() []int {
+	return dc.data.ListNumbers
+}
+
+func (dc *diskCache) listETag() string {
+	return dc.data.ListETag
+}
+
+func (dc *diskCache) putList(numbers []int, etag string) {
+	dc.data.ListNumbers = numbers
+	dc.data.ListETag = etag
+	dc.data.ListFetchedAt = time.Now()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Ref identifies a single pull/merge request on any forge: the API scheme and
+// host, the owner/repo (or group/project, for GitLab) it lives in, and its
+// PR/MR number. Scheme is only set and used by the Gitea backend, whose
+// self-hosted PR URLs can legitimately be plain http.
+type Ref struct {
+	Scheme string
+	Host   string
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// Forge abstracts the code-hosting API a stack lives on. All stack-detection logic
+// (extractDependencies, extractStackInfo, topic clustering, branch chaining) stays
+// forge-agnostic and operates purely on the common PullRequest struct returned here.
+type Forge interface {
+	GetPR(ctx context.Context, ref Ref) (*PullRequest, error)
+	ListOpenPRs(ctx context.Context, ref Ref) ([]int, error)
+	ParseURL(raw string) (Ref, error)
+	AuthToken() (string, error)
+}
+
+// resolveForge selects a Forge implementation by the PR URL's host, unless override
+// (the --forge flag) forces a specific one — needed for self-hosted Gitea
+// instances that resolveForge can't identify from the host alone. The GitLab
+// backend only accepts gitlab.com URLs, so self-hosted GitLab is not handled.
+func resolveForge(rawURL, override, tokenFlag, apiMode string, noCache, refresh bool) (Forge, error) {
+	name := override
+	if name == "" {
+		host, err := hostOf(rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		switch host {
+		case "github.com":
+			name = "github"
+		case "gitlab.com":
+			name = "gitlab"
+		default:
+			return nil, fmt.Errorf("cannot determine forge for host %q; pass --forge=github|gitlab|gitea", host)
+		}
+	}
+
+	switch name {
+	case "github":
+		return newGitHubForge(tokenFlag, apiMode, noCache, refresh), nil
+	case "gitlab":
+		return newGitLabForge(tokenFlag), nil
+	case "gitea":
+		return newGiteaForge(tokenFlag), nil
+	default:
+		return nil, fmt.Errorf("unknown --forge %q: must be one of github, gitlab, gitea", name)
+	}
+}
+
+func hostOf(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid PR/MR URL: %s", rawURL)
+	}
+	return u.Host, nil
+}
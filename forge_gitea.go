@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// giteaForge implements Forge against the Gitea REST v1 API. Gitea is typically
+// self-hosted, so unlike githubForge/gitlabForge the API host comes from the PR
+// URL itself (Ref.Host) rather than being hardcoded.
+type giteaForge struct {
+	tokenFlag string
+	token     string
+	client    *http.Client
+}
+
+func newGiteaForge(tokenFlag string) *giteaForge {
+	return &giteaForge{tokenFlag: tokenFlag, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+var giteaPullURLPattern = regexp.MustCompile(`(https?)://([^/]+)/([^/]+)/([^/]+)/pulls/(\d+)`)
+
+func (ge *giteaForge) ParseURL(raw string) (Ref, error) {
+	matches := giteaPullURLPattern.FindStringSubmatch(raw)
+	if len(matches) != 6 {
+		return Ref{}, fmt.Errorf("invalid Gitea pull request URL format")
+	}
+
+	number, err := strconv.Atoi(matches[5])
+	if err != nil {
+		return Ref{}, err
+	}
+
+	return Ref{Scheme: matches[1], Host: matches[2], Owner: matches[3], Repo: matches[4], Number: number}, nil
+}
+
+func (ge *giteaForge) AuthToken() (string, error) {
+	if ge.token != "" {
+		return ge.token, nil
+	}
+
+	if ge.tokenFlag != "" {
+		ge.token = ge.tokenFlag
+		return ge.token, nil
+	}
+
+	if envToken := os.Getenv("GITEA_TOKEN"); envToken != "" {
+		ge.token = envToken
+		return ge.token, nil
+	}
+
+	return "", fmt.Errorf("GITEA_TOKEN not set. Export a Gitea access token or pass --token")
+}
+
+func (ge *giteaForge) apiBase(ref Ref) string {
+	scheme := ref.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/api/v1/repos/%s/%s", scheme, ref.Host, ref.Owner, ref.Repo)
+}
+
+func (ge *giteaForge) GetPR(ctx context.Context, ref Ref) (*PullRequest, error) {
+	apiURL := fmt.Sprintf("%s/pulls/%d", ge.apiBase(ref), ref.Number)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+ge.token)
+
+	resp, err := ge.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Gitea API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var pull struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Body   string `json:"body"`
+		State  string `json:"state"`
+		Draft  bool   `json:"draft"`
+		Base   struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pull); err != nil {
+		return nil, err
+	}
+
+	state := pull.State
+	if pull.Draft {
+		state = "draft"
+	}
+
+	pr := &PullRequest{
+		Number:     pull.Number,
+		Title:      pull.Title,
+		Body:       pull.Body,
+		State:      state,
+		BaseBranch: pull.Base.Ref,
+		HeadBranch: pull.Head.Ref,
+	}
+	pr.Dependencies = extractDependencies(pr.Body)
+
+	return pr, nil
+}
+
+func (ge *giteaForge) ListOpenPRs(ctx context.Context, ref Ref) ([]int, error) {
+	apiURL := fmt.Sprintf("%s/pulls?state=open&limit=50", ge.apiBase(ref))
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+ge.token)
+
+	resp, err := ge.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Gitea API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var pulls []struct {
+		Number int `json:"number"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pulls); err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int, len(pulls))
+	for i, p := range pulls {
+		numbers[i] = p.Number
+	}
+	return numbers, nil
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// githubForge implements Forge against the GitHub REST and GraphQL v4 APIs. It also
+// owns the disk-cache and rate-limit-retry machinery from the earlier GitHub-specific
+// work, since ETag/X-RateLimit-* headers and the GraphQL RATE_LIMITED error type are
+// particular to GitHub, not part of the generic Forge contract.
+type githubForge struct {
+	tokenFlag string
+	token     string
+	client    *http.Client
+	apiMode   string
+	noCache   bool
+	refresh   bool
+	cacheTTL  time.Duration
+
+	diskCaches map[string]*diskCache
+	memCache   map[int]*PullRequest
+}
+
+func newGitHubForge(tokenFlag, apiMode string, noCache, refresh bool) *githubForge {
+	return &githubForge{
+		tokenFlag:  tokenFlag,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		apiMode:    apiMode,
+		noCache:    noCache,
+		refresh:    refresh,
+		cacheTTL:   defaultCacheTTL,
+		diskCaches: make(map[string]*diskCache),
+		memCache:   make(map[int]*PullRequest),
+	}
+}
+
+var githubPullURLPattern = regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/pull/(\d+)`)
+
+func (gh *githubForge) ParseURL(raw string) (Ref, error) {
+	matches := githubPullURLPattern.FindStringSubmatch(raw)
+	if len(matches) != 4 {
+		return Ref{}, fmt.Errorf("invalid GitHub PR URL format")
+	}
+
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return Ref{}, err
+	}
+
+	return Ref{Host: "github.com", Owner: matches[1], Repo: matches[2], Number: number}, nil
+}
+
+// AuthToken resolves the token in priority order (--token flag, GITHUB_TOKEN env var,
+// `gh auth token`) and caches the result for subsequent GetPR/ListOpenPRs calls.
+func (gh *githubForge) AuthToken() (string, error) {
+	if gh.token != "" {
+		return gh.token, nil
+	}
+
+	if gh.tokenFlag != "" {
+		gh.token = gh.tokenFlag
+		return gh.token, nil
+	}
+
+	if envToken := os.Getenv("GITHUB_TOKEN"); envToken != "" {
+		gh.token = envToken
+		return gh.token, nil
+	}
+
+	output, err := exec.Command("gh", "auth", "token").Output()
+	if err != nil {
+		return "", fmt.Errorf("gh CLI not authenticated and GITHUB_TOKEN not set. Run: gh auth login")
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("empty token from gh CLI")
+	}
+
+	gh.token = token
+	return token, nil
+}
+
+// getDiskCache는 owner/repo별 디스크 캐시를 찾아 열거나, 없으면 새로 만들어 메모이즈한다.
+// --no-cache가 켜져 있으면 nil을 반환해 디스크 캐시를 완전히 건너뛴다.
+func (gh *githubForge) getDiskCache(owner, repo string) (*diskCache, error) {
+	if gh.noCache {
+		return nil, nil
+	}
+
+	key := owner + "/" + repo
+	if dc, ok := gh.diskCaches[key]; ok {
+		return dc, nil
+	}
+
+	dc, err := newDiskCache(owner, repo, gh.cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	gh.diskCaches[key] = dc
+	return dc, nil
+}
+
+func (gh *githubForge) GetPR(ctx context.Context, ref Ref) (*PullRequest, error) {
+	if pr, ok := gh.memCache[ref.Number]; ok {
+		return pr, nil
+	}
+
+	dc, err := gh.getDiskCache(ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if dc != nil && !gh.refresh {
+		if pr := dc.fresh(ref.Number); pr != nil {
+			return pr, nil
+		}
+	}
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", ref.Owner, ref.Repo, ref.Number)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "token "+gh.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if dc != nil && !gh.refresh {
+		if etag := dc.etag(ref.Number); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	resp, err := gh.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && dc != nil {
+		if pr := dc.stale(ref.Number); pr != nil {
+			dc.put(ref.Number, pr, dc.etag(ref.Number))
+			saveDiskCache(dc)
+			return pr, nil
+		}
+	}
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var prData map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&prData); err != nil {
+		return nil, err
+	}
+
+	// base와 head 브랜치 정보 추출
+	baseBranch := ""
+	headBranch := ""
+	if base, ok := prData["base"].(map[string]interface{}); ok {
+		if refName, ok := base["ref"].(string); ok {
+			baseBranch = refName
+		}
+	}
+	if head, ok := prData["head"].(map[string]interface{}); ok {
+		if refName, ok := head["ref"].(string); ok {
+			headBranch = refName
+		}
+	}
+
+	// body는 description 없는 PR이면 JSON null로 와서 string 단언이 패닉하니 comma-ok로 받는다
+	body, _ := prData["body"].(string)
+
+	state, _ := prData["state"].(string)
+	if draft, _ := prData["draft"].(bool); draft {
+		state = "draft"
+	}
+
+	pr := &PullRequest{
+		Number:     int(prData["number"].(float64)),
+		Title:      prData["title"].(string),
+		Body:       body,
+		State:      state,
+		BaseBranch: baseBranch,
+		HeadBranch: headBranch,
+	}
+	pr.Dependencies = extractDependencies(pr.Body)
+
+	if dc != nil {
+		dc.put(ref.Number, pr, resp.Header.Get("ETag"))
+		saveDiskCache(dc)
+	}
+
+	return pr, nil
+}
+
+func (gh *githubForge) ListOpenPRs(ctx context.Context, ref Ref) ([]int, error) {
+	if gh.apiMode == "rest" {
+		return gh.listOpenPRsREST(ctx, ref)
+	}
+	return gh.listOpenPRsGraphQL(ctx, ref)
+}
+
+// listOpenPRsREST는 --api=rest일 때 쓰이는 PR당 1회 호출 경로다.
+func (gh *githubForge) listOpenPRsREST(ctx context.Context, ref Ref) ([]int, error) {
+	dc, err := gh.getDiskCache(ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if dc != nil && !gh.refresh {
+		if numbers := dc.freshList(); numbers != nil {
+			return numbers, nil
+		}
+	}
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?state=open&per_page=100", ref.Owner, ref.Repo)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "token "+gh.token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if dc != nil && !gh.refresh {
+		if etag := dc.listETag(); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	resp, err := gh.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && dc != nil {
+		numbers := dc.staleList()
+		dc.putList(numbers, dc.listETag())
+		saveDiskCache(dc)
+		return numbers, nil
+	}
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var prs []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&prs); err != nil {
+		return nil, err
+	}
+
+	var prNumbers []int
+	for _, pr := range prs {
+		prNumbers = append(prNumbers, int(pr["number"].(float64)))
+	}
+
+	if dc != nil {
+		dc.putList(prNumbers, resp.Header.Get("ETag"))
+		saveDiskCache(dc)
+	}
+
+	return prNumbers, nil
+}
+
+// resetMemCache clears the process-lifetime PR memo so the next GetPR call
+// goes through the disk cache's TTL/If-None-Match path again
+// instead of returning the first-run snapshot forever.
+func (gh *githubForge) resetMemCache() {
+	gh.memCache = make(map[int]*PullRequest)
+}
+
+// saveDiskCache는 캐시 저장 실패를 치명적 에러로 취급하지 않고 경고만 남긴다 —
+// 디스크 캐시는 순수한 최적화이지, 없어도 툴은 정상 동작해야 한다.
+func saveDiskCache(dc *diskCache) {
+	if err := dc.save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save PR cache: %v\n", err)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// gitlabForge implements Forge against the GitLab REST v4 API.
+type gitlabForge struct {
+	tokenFlag string
+	token     string
+	client    *http.Client
+}
+
+func newGitLabForge(tokenFlag string) *gitlabForge {
+	return &gitlabForge{tokenFlag: tokenFlag, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+var gitlabMRURLPattern = regexp.MustCompile(`gitlab\.com/([^/]+)/([^/]+)/-/merge_requests/(\d+)`)
+
+func (gl *gitlabForge) ParseURL(raw string) (Ref, error) {
+	matches := gitlabMRURLPattern.FindStringSubmatch(raw)
+	if len(matches) != 4 {
+		return Ref{}, fmt.Errorf("invalid GitLab merge request URL format")
+	}
+
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return Ref{}, err
+	}
+
+	return Ref{Host: "gitlab.com", Owner: matches[1], Repo: matches[2], Number: number}, nil
+}
+
+func (gl *gitlabForge) AuthToken() (string, error) {
+	if gl.token != "" {
+		return gl.token, nil
+	}
+
+	if gl.tokenFlag != "" {
+		gl.token = gl.tokenFlag
+		return gl.token, nil
+	}
+
+	if envToken := os.Getenv("GITLAB_TOKEN"); envToken != "" {
+		gl.token = envToken
+		return gl.token, nil
+	}
+
+	return "", fmt.Errorf("GITLAB_TOKEN not set. Export a GitLab personal access token or pass --token")
+}
+
+func (gl *gitlabForge) projectPath(ref Ref) string {
+	return url.PathEscape(ref.Owner + "/" + ref.Repo)
+}
+
+func (gl *gitlabForge) GetPR(ctx context.Context, ref Ref) (*PullRequest, error) {
+	apiURL := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/merge_requests/%d", gl.projectPath(ref), ref.Number)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", gl.token)
+
+	resp, err := gl.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var mr struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		State        string `json:"state"`
+		Draft        bool   `json:"draft"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, err
+	}
+
+	pr := &PullRequest{
+		Number:     mr.IID,
+		Title:      mr.Title,
+		Body:       mr.Description,
+		State:      gitlabState(mr.State, mr.Draft),
+		BaseBranch: mr.TargetBranch,
+		HeadBranch: mr.SourceBranch,
+	}
+	pr.Dependencies = extractDependencies(pr.Body)
+
+	return pr, nil
+}
+
+// gitlabState maps GitLab's "opened"/"closed"/"merged" states onto the same
+// open/closed/draft vocabulary the renderers already understand.
+func gitlabState(state string, draft bool) string {
+	if draft {
+		return "draft"
+	}
+	if state == "opened" {
+		return "open"
+	}
+	return state
+}
+
+func (gl *gitlabForge) ListOpenPRs(ctx context.Context, ref Ref) ([]int, error) {
+	apiURL := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/merge_requests?state=opened&per_page=100", gl.projectPath(ref))
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", gl.token)
+
+	resp, err := gl.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitLab API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var mrs []struct {
+		IID int `json:"iid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&mrs); err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int, len(mrs))
+	for i, mr := range mrs {
+		numbers[i] = mr.IID
+	}
+	return numbers, nil
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const githubGraphQLURL = "https://api.github.com/graphql"
+
+const openPRsQuery = `
+query($owner: String!, $repo: String!, $cursor: String) {
+  rateLimit {
+    resetAt
+  }
+  repository(owner: $owner, name: $repo) {
+    pullRequests(states: OPEN, first: 100, after: $cursor) {
+      nodes {
+        number
+        title
+        state
+        isDraft
+        baseRefName
+        headRefName
+        body
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type prNode struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	IsDraft     bool   `json:"isDraft"`
+	BaseRefName string `json:"baseRefName"`
+	HeadRefName string `json:"headRefName"`
+	Body        string `json:"body"`
+}
+
+type pullRequestsPage struct {
+	Nodes    []prNode `json:"nodes"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// graphQLRateLimit carries the rateLimit{resetAt} hint the query asks for, so a
+// RATE_LIMITED error can wait exactly until reset instead of blind backoff.
+type graphQLRateLimit struct {
+	ResetAt string `json:"resetAt"`
+}
+
+type repositoryData struct {
+	RateLimit  *graphQLRateLimit `json:"rateLimit"`
+	Repository struct {
+		PullRequests pullRequestsPage `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+// listOpenPRsGraphQL은 열린 PR 전체를 페이지네이션된 GraphQL 쿼리로 가져온다.
+// REST 경로의 PR당 1회 호출과 달리, 페이지당 1회 호출(최대 100개씩)로 끝나고
+// 가져온 PR들은 곧바로 gh.memCache에 채워져 이후 GetPR 호출이 추가 요청 없이 끝난다.
+//
+// GitHub의 GraphQL API는 REST와 달리 ETag/If-None-Match 조건부 요청을 지원하지
+// 않으므로, diskCache에는 ETag 없이(빈 문자열로) 기록해 TTL 동안만 재사용한다 —
+// TTL이 지나면 REST 경로처럼 304로 넘어가는 대신 그냥 다시 전체를 가져온다.
+func (gh *githubForge) listOpenPRsGraphQL(ctx context.Context, ref Ref) ([]int, error) {
+	dc, err := gh.getDiskCache(ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if dc != nil && !gh.refresh {
+		if numbers := dc.freshList(); numbers != nil {
+			return numbers, nil
+		}
+	}
+
+	var cursor *string
+	var numbers []int
+
+	for {
+		variables := map[string]interface{}{
+			"owner":  ref.Owner,
+			"repo":   ref.Repo,
+			"cursor": cursor,
+		}
+
+		page, err := gh.runGraphQLQuery(ctx, openPRsQuery, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range page.Nodes {
+			pr := &PullRequest{
+				Number:     node.Number,
+				Title:      node.Title,
+				Body:       node.Body,
+				State:      strings.ToLower(node.State),
+				BaseBranch: node.BaseRefName,
+				HeadBranch: node.HeadRefName,
+			}
+			if node.IsDraft {
+				pr.State = "draft"
+			}
+			pr.Dependencies = extractDependencies(pr.Body)
+			gh.memCache[pr.Number] = pr
+			numbers = append(numbers, pr.Number)
+
+			if dc != nil {
+				dc.put(pr.Number, pr, "")
+			}
+		}
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := page.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+
+	if dc != nil {
+		dc.putList(numbers, "")
+		saveDiskCache(dc)
+	}
+
+	return numbers, nil
+}
+
+// runGraphQLQuery는 GraphQL 요청을 보내고, errors[].type == "RATE_LIMITED" 응답을
+// graphQLRetryDelay가 계산한 대기 시간만큼 쉬었다가 재시도한다 (최대 3회 시도). 쿼리가 함께
+// 요청한 rateLimit.resetAt이 있으면 그걸 우선 쓰고, 없으면 HTTP 헤더/지수 백오프로 넘어간다.
+func (gh *githubForge) runGraphQLQuery(ctx context.Context, query string, variables map[string]interface{}) (*pullRequestsPage, error) {
+	reqBody, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	const maxAttempts = 3
+	var lastErr error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		resp, err := withRetry(func() (*http.Response, error) {
+			req, err := http.NewRequestWithContext(ctx, "POST", githubGraphQLURL, bytes.NewReader(reqBody))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Authorization", "bearer "+gh.token)
+			req.Header.Set("Content-Type", "application/json")
+			return gh.client.Do(req)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("GitHub GraphQL API error %d: %s", resp.StatusCode, string(body))
+		}
+
+		var gqlResp graphQLResponse
+		if err := json.Unmarshal(body, &gqlResp); err != nil {
+			return nil, err
+		}
+
+		var data repositoryData
+		if len(gqlResp.Data) > 0 {
+			if err := json.Unmarshal(gqlResp.Data, &data); err != nil {
+				return nil, err
+			}
+		}
+
+		rateLimited := false
+		for _, gqlErr := range gqlResp.Errors {
+			if gqlErr.Type == "RATE_LIMITED" {
+				rateLimited = true
+				lastErr = fmt.Errorf("GitHub GraphQL rate limited: %s", gqlErr.Message)
+				break
+			}
+		}
+
+		if rateLimited {
+			if attempt < maxAttempts-1 {
+				time.Sleep(graphQLRetryDelay(resp, attempt, data.RateLimit))
+			}
+			continue
+		}
+
+		if len(gqlResp.Errors) > 0 {
+			return nil, fmt.Errorf("GitHub GraphQL error: %s", gqlResp.Errors[0].Message)
+		}
+
+		return &data.Repository.PullRequests, nil
+	}
+
+	return nil, lastErr
+}
+
+// withRetry는 fn이 반환한 응답이 rate limit(403/429 + X-RateLimit-Remaining: 0, 혹은
+// Retry-After 헤더)에 걸렸는지 확인하고, 걸렸다면 retryDelay만큼 쉬었다가 다시 시도한다
+// (첫 시도 포함 최대 3회).
+func withRetry(fn func() (*http.Response, error)) (*http.Response, error) {
+	const maxAttempts = 3
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		resp, err = fn()
+		if err != nil {
+			return nil, err
+		}
+
+		if !isRateLimited(resp) {
+			return resp, nil
+		}
+
+		if attempt == maxAttempts-1 {
+			break
+		}
+
+		wait := retryDelay(resp, attempt)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		time.Sleep(wait)
+	}
+
+	return resp, nil
+}
+
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+}
+
+// graphQLRetryDelay prefers the GraphQL response's rateLimit.resetAt hint (GitHub's
+// GraphQL rate limit resets on its own schedule, not the REST X-RateLimit-Reset one)
+// and falls back to retryDelay's header/backoff logic when resetAt is absent or unparsable.
+func graphQLRetryDelay(resp *http.Response, attempt int, rateLimit *graphQLRateLimit) time.Duration {
+	if rateLimit != nil && rateLimit.ResetAt != "" {
+		if resetAt, err := time.Parse(time.RFC3339, rateLimit.ResetAt); err == nil {
+			if d := time.Until(resetAt); d > 0 {
+				return d
+			}
+		}
+	}
+	return retryDelay(resp, attempt)
+}
+
+// retryDelay는 다음 시도까지 대기할 시간을 계산한다: Retry-After나 X-RateLimit-Reset
+// 헤더가 있으면 그 값을 우선하고, 없으면 1s/2s/4s 지수 백오프에 최대 250ms의 jitter를 더한다.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if ts, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			if d := time.Until(time.Unix(ts, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+
+	backoff := time.Duration(1<<uint(attempt)) * time.Second
+	jitter := time.Duration(rand.Intn(250)) * time.Millisecond
+	return backoff + jitter
+}
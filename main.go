@@ -1,17 +1,13 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"os/exec"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -26,78 +22,57 @@ type PullRequest struct {
 	Dependencies []int  `json:"-"`
 }
 
+// StackVisualizer holds all forge-agnostic stack-detection logic. It talks to the
+// actual code host only through the Forge interface, so the same detection code
+// (stack: body block, topic clustering, branch chaining, dependency traversal)
+// works unmodified against GitHub, GitLab, or Gitea.
 type StackVisualizer struct {
-	token  string
-	client *http.Client
-	cache  map[int]*PullRequest
+	forge          Forge
+	host           string
+	cache          map[int]*PullRequest
+	openPRNumbers  []int
+	topicExtractor *TopicExtractor
 }
 
-func NewStackVisualizer(token string) *StackVisualizer {
+func NewStackVisualizer(forge Forge, host, topicPattern string) (*StackVisualizer, error) {
+	topicExtractor, err := NewTopicExtractor(topicPattern)
+	if err != nil {
+		return nil, err
+	}
+
 	return &StackVisualizer{
-		token:  token,
-		client: &http.Client{Timeout: 30 * time.Second},
-		cache:  make(map[int]*PullRequest),
+		forge:          forge,
+		host:           host,
+		cache:          make(map[int]*PullRequest),
+		topicExtractor: topicExtractor,
+	}, nil
+}
+
+// resetCaches clears the in-process memoization (sv.cache, sv.openPRNumbers, and
+// the forge's own memCache if it has one) so the next fetchPR/listOpenPRs call
+// falls through to the forge's disk cache instead of replaying the first-run
+// snapshot forever. Needed by long-lived callers like the tui subcommand, whose
+// periodic/manual refreshes would otherwise never observe a PR state change.
+func (sv *StackVisualizer) resetCaches() {
+	sv.cache = make(map[int]*PullRequest)
+	sv.openPRNumbers = nil
+	if r, ok := sv.forge.(interface{ resetMemCache() }); ok {
+		r.resetMemCache()
 	}
 }
 
-// GitHub API 호출
+// fetchPR은 sv.cache로 in-run 메모이제이션만 하고, 실제 조회는 forge에 위임한다.
 func (sv *StackVisualizer) fetchPR(owner, repo string, number int) (*PullRequest, error) {
 	if pr, exists := sv.cache[number]; exists {
 		return pr, nil
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", owner, repo, number)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "token "+sv.token)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := sv.client.Do(req)
+	pr, err := sv.forge.GetPR(context.Background(), Ref{Host: sv.host, Owner: owner, Repo: repo, Number: number})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var prData map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&prData); err != nil {
-		return nil, err
-	}
-
-	// base와 head 브랜치 정보 추출
-	baseBranch := ""
-	headBranch := ""
-	if base, ok := prData["base"].(map[string]interface{}); ok {
-		if ref, ok := base["ref"].(string); ok {
-			baseBranch = ref
-		}
-	}
-	if head, ok := prData["head"].(map[string]interface{}); ok {
-		if ref, ok := head["ref"].(string); ok {
-			headBranch = ref
-		}
-	}
-
-	pr := &PullRequest{
-		Number:     int(prData["number"].(float64)),
-		Title:      prData["title"].(string),
-		Body:       prData["body"].(string),
-		State:      prData["state"].(string),
-		BaseBranch: baseBranch,
-		HeadBranch: headBranch,
-	}
 
-	// Dependency 추출
-	pr.Dependencies = extractDependencies(pr.Body)
 	sv.cache[number] = pr
-
 	return pr, nil
 }
 
@@ -173,39 +148,21 @@ func extractStackDependencies(body string) []int {
 	return nil // 이제 사용하지 않음
 }
 
-// 모든 열린 PR 목록 가져오기
-func (sv *StackVisualizer) fetchAllOpenPRs(owner, repo string) ([]int, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?state=open&per_page=100", owner, repo)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// listOpenPRs는 forge에 열린 PR 번호 목록을 요청한다. GitHub의 경우 apiMode에 따라
+// GraphQL(기본) 또는 REST 경로를 쓰지만, 그 선택은 forge 내부에 캡슐화되어 있다.
+// 결과는 buildStackGraph 안에서 재사용될 수 있도록 캐시해 둔다.
+func (sv *StackVisualizer) listOpenPRs(owner, repo string) ([]int, error) {
+	if sv.openPRNumbers != nil {
+		return sv.openPRNumbers, nil
 	}
 
-	req.Header.Set("Authorization", "token "+sv.token)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := sv.client.Do(req)
+	numbers, err := sv.forge.ListOpenPRs(context.Background(), Ref{Host: sv.host, Owner: owner, Repo: repo})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var prs []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&prs); err != nil {
-		return nil, err
-	}
 
-	var prNumbers []int
-	for _, pr := range prs {
-		prNumbers = append(prNumbers, int(pr["number"].(float64)))
-	}
-
-	return prNumbers, nil
+	sv.openPRNumbers = numbers
+	return numbers, nil
 }
 
 // PR이 다른 PR에 의존하는지 확인
@@ -285,7 +242,7 @@ func (sv *StackVisualizer) buildStackGraph(owner, repo string, startPR int) ([]*
 	}
 
 	// stack 형식이 없으면 다른 PR들의 stack 정보에서 이 PR이 포함된 것을 찾아보기
-	allPRs, err := sv.fetchAllOpenPRs(owner, repo)
+	allPRs, err := sv.listOpenPRs(owner, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch all PRs: %w", err)
 	}
@@ -322,6 +279,12 @@ func (sv *StackVisualizer) buildStackGraph(owner, repo string, startPR int) ([]*
 		}
 	}
 
+	// stack 정보도 없으면 topic 기반 네이밍 컨벤션(ghstack, spr, <user>/stack/<name>/<n>,
+	// 혹은 Stack-Topic: 트레일러)으로 같은 토픽의 PR들을 묶어보기
+	if topicStack, err := sv.clusterByTopic(owner, repo, startPRData, allPRs); err == nil && len(topicStack) > 1 {
+		return topicStack, nil
+	}
+
 	// stack 정보를 찾지 못했으면 브랜치 관계 기반으로 관련 PR들 찾기
 	relatedPRs, err := sv.findRelatedPRsByBranch(owner, repo, startPRData, allPRs)
 	if err == nil && len(relatedPRs) > 0 {
@@ -426,121 +389,90 @@ func (sv *StackVisualizer) buildStackGraph(owner, repo string, startPR int) ([]*
 	return stack, nil
 }
 
-// 간단한 텍스트 스택 출력
-func printStack(stack []*PullRequest, currentPR int) {
-	// 첫 번째 형식: 상세 정보
-	fmt.Println("\nstack:")
-	
-	for _, pr := range stack {
-		marker := ""
-		if pr.Number == currentPR {
-			marker = " <-"
-		}
-		
-		status := "open"
-		if pr.State == "closed" {
-			status = "closed"
-		} else if pr.State == "draft" {
-			status = "draft"
-		}
-		
-		fmt.Printf("- #%d (%s): %s%s\n", pr.Number, status, pr.Title, marker)
-	}
-	
-	// 구분선
-	fmt.Println("--------")
-	
-	// 두 번째 형식: git pr 용 간단한 형식 (숫자만)
-	for _, pr := range stack {
-		marker := ""
-		if pr.Number == currentPR {
-			marker = " <-"
-		}
-		fmt.Printf("- #%d%s\n", pr.Number, marker)
-	}
-}
-
-// GitHub 토큰 가져오기
-func getGitHubToken(flagToken string) (string, error) {
-	if flagToken != "" {
-		return flagToken, nil
-	}
-
-	cmd := exec.Command("gh", "auth", "token")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("gh CLI not authenticated. Run: gh auth login")
-	}
-	
-	token := strings.TrimSpace(string(output))
-	if token == "" {
-		return "", fmt.Errorf("empty token from gh CLI")
-	}
-	
-	return token, nil
-}
-
-// URL 파싱
-func parseGitHubURL(url string) (owner, repo string, prNumber int, err error) {
-	re := regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/pull/(\d+)`)
-	matches := re.FindStringSubmatch(url)
-	
-	if len(matches) != 4 {
-		return "", "", 0, fmt.Errorf("invalid GitHub PR URL format")
-	}
-
-	owner = matches[1]
-	repo = matches[2]
-	prNumber, err = strconv.Atoi(matches[3])
-	
-	return
-}
-
 func main() {
 	var token string
+	var forgeName string
+	var apiMode string
+	var topicPattern string
+	var format string
+	var noCache bool
+	var refresh bool
 
 	rootCmd := &cobra.Command{
 		Use:   "stacked-pr [PR_URL]",
-		Short: "Show stacked GitHub PRs in simple text format",
-		Long: `A minimal CLI tool to analyze GitHub PR dependencies.
+		Short: "Show stacked PRs/MRs as text, Mermaid, Graphviz, or JSON",
+		Long: `A minimal CLI tool to analyze stacked pull/merge request dependencies
+across GitHub, GitLab, and Gitea.
 
-Requires gh CLI authentication: gh auth login
+GitHub requires gh CLI authentication (gh auth login) unless --token or
+GITHUB_TOKEN is set. GitLab and Gitea require --token or GITLAB_TOKEN /
+GITEA_TOKEN.
 
 Examples:
-  stacked-pr https://github.com/owner/repo/pull/123`,
+  stacked-pr https://github.com/owner/repo/pull/123
+  stacked-pr https://gitlab.com/owner/repo/-/merge_requests/45
+  stacked-pr --forge gitea https://git.example.com/owner/repo/pulls/7`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			prURL := args[0]
 
-			// 토큰 가져오기
-			resolvedToken, err := getGitHubToken(token)
+			if apiMode != "rest" && apiMode != "graphql" {
+				return fmt.Errorf("invalid --api value %q: must be \"rest\" or \"graphql\"", apiMode)
+			}
+
+			renderer, err := NewRenderer(format)
 			if err != nil {
 				return err
 			}
 
+			forge, err := resolveForge(prURL, forgeName, token, apiMode, noCache, refresh)
+			if err != nil {
+				return err
+			}
+
+			// 토큰 가져오기 (forge 내부에 캐시되어 이후 GetPR/ListOpenPRs에서 재사용됨)
+			if _, err := forge.AuthToken(); err != nil {
+				return err
+			}
+
 			// URL 파싱
-			owner, repo, prNumber, err := parseGitHubURL(prURL)
+			ref, err := forge.ParseURL(prURL)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Analyzing %s/%s #%d...\n", owner, repo, prNumber)
+			fmt.Printf("Analyzing %s/%s #%d...\n", ref.Owner, ref.Repo, ref.Number)
 
 			// Stack 분석
-			visualizer := NewStackVisualizer(resolvedToken)
-			stack, err := visualizer.buildStackGraph(owner, repo, prNumber)
+			visualizer, err := NewStackVisualizer(forge, ref.Host, topicPattern)
+			if err != nil {
+				return err
+			}
+			stack, err := visualizer.buildStackGraph(ref.Owner, ref.Repo, ref.Number)
 			if err != nil {
 				return err
 			}
 
 			// 스택 출력
-			printStack(stack, prNumber)
+			output, err := renderer.Render(stack, ref.Number)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
 
 			return nil
 		},
 	}
 
-	rootCmd.Flags().StringVarP(&token, "token", "t", "", "GitHub personal access token")
+	rootCmd.Flags().StringVarP(&token, "token", "t", "", "access token for the target forge")
+	rootCmd.Flags().StringVar(&forgeName, "forge", "", `forge backend to use: "github", "gitlab", or "gitea" (autodetected from the URL host when omitted)`)
+	rootCmd.Flags().StringVar(&apiMode, "api", "graphql", `GitHub API to use: "rest" or "graphql" (ignored for other forges)`)
+	rootCmd.Flags().StringVar(&topicPattern, "topic-pattern", "", "custom regex (with (?P<topic>...) and optional (?P<index>\\d+) groups) for detecting stacked-diff head branches")
+	rootCmd.Flags().StringVar(&format, "format", "text", "output format: text, mermaid, dot, or json")
+	rootCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable the on-disk PR cache entirely")
+	rootCmd.Flags().BoolVar(&refresh, "refresh", false, "bypass cached/ETag-validated data and force a fresh fetch")
+
+	rootCmd.AddCommand(newTUICommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Renderer turns a built stack graph into one specific output format. Implementations
+// are pure functions of the graph so they can be reused by the text CLI, the TUI, or
+// external tooling that just wants the JSON schema.
+type Renderer interface {
+	Render(stack []*PullRequest, currentPR int) (string, error)
+}
+
+// NewRenderer resolves the --format flag to a concrete Renderer.
+func NewRenderer(format string) (Renderer, error) {
+	switch format {
+	case "text":
+		return textRenderer{}, nil
+	case "mermaid":
+		return mermaidRenderer{}, nil
+	case "dot":
+		return dotRenderer{}, nil
+	case "json":
+		return jsonRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown --format %q: must be one of text, mermaid, dot, json", format)
+	}
+}
+
+// stackEdge describes one base->dependent relationship in the stack, either derived
+// from an explicit "depends on #N" style dependency or, failing that, from the
+// order buildStackGraph already placed the PRs in (base-most first).
+type stackEdge struct {
+	From int
+	To   int
+	Kind string
+}
+
+func stackEdges(stack []*PullRequest) []stackEdge {
+	inStack := make(map[int]bool, len(stack))
+	for _, pr := range stack {
+		inStack[pr.Number] = true
+	}
+
+	var edges []stackEdge
+	for i, pr := range stack {
+		explicit := false
+		for _, dep := range pr.Dependencies {
+			if inStack[dep] {
+				edges = append(edges, stackEdge{From: dep, To: pr.Number, Kind: "depends"})
+				explicit = true
+			}
+		}
+		if !explicit && i > 0 {
+			edges = append(edges, stackEdge{From: stack[i-1].Number, To: pr.Number, Kind: "order"})
+		}
+	}
+	return edges
+}
+
+// textRenderer reproduces the CLI's original two-list plain-text output.
+type textRenderer struct{}
+
+func (textRenderer) Render(stack []*PullRequest, currentPR int) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("\nstack:\n")
+	for _, pr := range stack {
+		marker := ""
+		if pr.Number == currentPR {
+			marker = " <-"
+		}
+
+		status := "open"
+		if pr.State == "closed" {
+			status = "closed"
+		} else if pr.State == "draft" {
+			status = "draft"
+		}
+
+		fmt.Fprintf(&b, "- #%d (%s): %s%s\n", pr.Number, status, pr.Title, marker)
+	}
+
+	b.WriteString("--------\n")
+	for _, pr := range stack {
+		marker := ""
+		if pr.Number == currentPR {
+			marker = " <-"
+		}
+		fmt.Fprintf(&b, "- #%d%s\n", pr.Number, marker)
+	}
+
+	return b.String(), nil
+}
+
+// mermaidRenderer emits a `graph TD` block, styling the current PR with :::current
+// and closed PRs with :::closed so it can be dropped straight into docs or a web viewer.
+type mermaidRenderer struct{}
+
+func (mermaidRenderer) Render(stack []*PullRequest, currentPR int) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("graph TD\n")
+	for _, pr := range stack {
+		class := ""
+		if pr.Number == currentPR {
+			class = ":::current"
+		} else if pr.State == "closed" {
+			class = ":::closed"
+		}
+		fmt.Fprintf(&b, "    PR%d[\"#%d: %s\"]%s\n", pr.Number, pr.Number, escapeLabel(pr.Title), class)
+	}
+
+	for _, edge := range stackEdges(stack) {
+		fmt.Fprintf(&b, "    PR%d --> PR%d\n", edge.From, edge.To)
+	}
+
+	b.WriteString("    classDef current fill:#4c9aff,stroke:#0747a6,color:#fff\n")
+	b.WriteString("    classDef closed fill:#ebecf0,stroke:#6b778c,color:#6b778c\n")
+
+	return b.String(), nil
+}
+
+// dotRenderer emits an equivalent Graphviz digraph, colored by PR state.
+type dotRenderer struct{}
+
+func (dotRenderer) Render(stack []*PullRequest, currentPR int) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("digraph stack {\n")
+	b.WriteString("    rankdir=TB;\n")
+
+	for _, pr := range stack {
+		color := "lightgreen"
+		switch pr.State {
+		case "closed":
+			color = "lightgrey"
+		case "draft":
+			color = "lightyellow"
+		}
+
+		style := "filled"
+		if pr.Number == currentPR {
+			style = "filled,bold"
+		}
+
+		fmt.Fprintf(&b, "    %d [label=\"#%d: %s\", style=\"%s\", fillcolor=\"%s\"];\n",
+			pr.Number, pr.Number, escapeLabel(pr.Title), style, color)
+	}
+
+	for _, edge := range stackEdges(stack) {
+		fmt.Fprintf(&b, "    %d -> %d;\n", edge.From, edge.To)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String(), nil
+}
+
+func escapeLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+// jsonRenderer emits a stable schema meant for scripting, e.g. deciding whether PR #X
+// needs a rebase after #Y merged.
+type jsonRenderer struct{}
+
+type jsonStackEntry struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+	Base         string `json:"base"`
+	Head         string `json:"head"`
+	Dependencies []int  `json:"dependencies"`
+	Current      bool   `json:"current"`
+}
+
+type jsonEdge struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Kind string `json:"kind"`
+}
+
+type jsonOutput struct {
+	Stack []jsonStackEntry `json:"stack"`
+	Edges []jsonEdge       `json:"edges"`
+}
+
+func (jsonRenderer) Render(stack []*PullRequest, currentPR int) (string, error) {
+	out := jsonOutput{
+		Stack: make([]jsonStackEntry, len(stack)),
+		Edges: []jsonEdge{},
+	}
+
+	for i, pr := range stack {
+		out.Stack[i] = jsonStackEntry{
+			Number:       pr.Number,
+			Title:        pr.Title,
+			State:        pr.State,
+			Base:         pr.BaseBranch,
+			Head:         pr.HeadBranch,
+			Dependencies: pr.Dependencies,
+			Current:      pr.Number == currentPR,
+		}
+	}
+
+	for _, edge := range stackEdges(stack) {
+		out.Edges = append(out.Edges, jsonEdge{From: edge.From, To: edge.To, Kind: edge.Kind})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
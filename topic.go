@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// defaultTopicPatterns recognize common stacked-diff tool head-branch naming
+// conventions: ghstack, spr, and the generic "<user>/stack/<name>/<n>" scheme.
+var defaultTopicPatterns = []string{
+	`^ghstack/(?P<topic>[^/]+)/(?P<index>\d+)/head$`,
+	`^spr/(?P<topic>[^/]+)/(?P<index>[0-9a-fA-F]+)$`,
+	`^(?P<user>[^/]+)/stack/(?P<name>[^/]+)/(?P<index>\d+)$`,
+}
+
+// stackTopicTrailer matches an explicit "Stack-Topic: <name>" trailer in a PR body,
+// agit-style (`-o topic=<name>`), for users who want to tag PRs manually rather than
+// relying on a branch naming convention.
+var stackTopicTrailer = regexp.MustCompile(`(?im)^Stack-Topic:\s*(\S+)\s*$`)
+
+// TopicExtractor groups PRs by a shared topic identifier derived from head-branch
+// naming conventions (or an explicit Stack-Topic trailer), so stacks created by
+// tools like ghstack or spr can be ordered without relying on PR body dependency text.
+type TopicExtractor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewTopicExtractor builds an extractor from the built-in patterns plus an optional
+// user-supplied --topic-pattern regex, which is tried first.
+func NewTopicExtractor(customPattern string) (*TopicExtractor, error) {
+	patterns := make([]*regexp.Regexp, 0, len(defaultTopicPatterns)+1)
+
+	if customPattern != "" {
+		re, err := regexp.Compile(customPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --topic-pattern: %w", err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	for _, p := range defaultTopicPatterns {
+		patterns = append(patterns, regexp.MustCompile(p))
+	}
+
+	return &TopicExtractor{patterns: patterns}, nil
+}
+
+// Extract returns the topic identifier and numeric index (within that topic) for a
+// PR, preferring an explicit Stack-Topic body trailer over branch-name conventions.
+// The index is -1 when it can't be determined (e.g. a trailer with no ordering hint).
+func (te *TopicExtractor) Extract(headBranch, body string) (topic string, index int, ok bool) {
+	if m := stackTopicTrailer.FindStringSubmatch(body); m != nil {
+		return m[1], -1, true
+	}
+
+	for _, re := range te.patterns {
+		matches := re.FindStringSubmatch(headBranch)
+		if matches == nil {
+			continue
+		}
+
+		var topicParts []string
+		index := -1
+		for i, name := range re.SubexpNames() {
+			switch name {
+			case "":
+				continue
+			case "index":
+				if n, err := strconv.Atoi(matches[i]); err == nil {
+					index = n
+				}
+			default:
+				topicParts = append(topicParts, matches[i])
+			}
+		}
+
+		if len(topicParts) == 0 {
+			continue
+		}
+
+		topic := topicParts[0]
+		for _, part := range topicParts[1:] {
+			topic += "/" + part
+		}
+		return topic, index, true
+	}
+
+	return "", -1, false
+}
+
+// indexedPR pairs a PR with the numeric index extracted from its topic so
+// clusterByTopic and contiguousRun can sort and split on it.
+type indexedPR struct {
+	pr    *PullRequest
+	index int
+}
+
+// clusterByTopic groups every open PR that shares a topic with startPR and orders
+// them by their numeric index, rather than the len(Dependencies) heuristic which
+// mis-sorts when every PR in a stack declares exactly one dependency.
+//
+// A shared topic alone isn't enough: conventions like ghstack key the topic on
+// the submitter's username, so every ghstack PR a user has open — even across
+// unrelated stacks — shares one topic. A numeric-index-adjacency check isn't
+// enough either, since ghstack's index is a global per-user counter: an unrelated
+// single-PR submission can land on an index immediately next to a real stack's
+// (e.g. stack A = 1,2,3 and unrelated stack B = 4,5 sort into one contiguous
+// 1..5 run). So once candidates are collected we walk out from startPR along
+// the run of adjacent indexes AND require each step to also be linked by branch
+// base/head, the same evidence findRelatedPRsByBranch uses — real stacked diffs
+// rebase each PR onto the previous one's branch, unrelated PRs don't.
+func (sv *StackVisualizer) clusterByTopic(owner, repo string, startPR *PullRequest, allPRs []int) ([]*PullRequest, error) {
+	if sv.topicExtractor == nil {
+		return nil, nil
+	}
+
+	startTopic, startIndex, ok := sv.topicExtractor.Extract(startPR.HeadBranch, startPR.Body)
+	if !ok {
+		return nil, nil
+	}
+
+	var members []indexedPR
+
+	for _, prNum := range allPRs {
+		pr, err := sv.fetchPR(owner, repo, prNum)
+		if err != nil {
+			continue
+		}
+
+		topic, index, ok := sv.topicExtractor.Extract(pr.HeadBranch, pr.Body)
+		if !ok || topic != startTopic {
+			continue
+		}
+		members = append(members, indexedPR{pr: pr, index: index})
+	}
+
+	if len(members) <= 1 {
+		return nil, nil
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].index != members[j].index {
+			return members[i].index < members[j].index
+		}
+		return members[i].pr.Number < members[j].pr.Number
+	})
+
+	members = contiguousRun(members, startPR.Number, startIndex)
+	if len(members) <= 1 {
+		return nil, nil
+	}
+
+	stack := make([]*PullRequest, len(members))
+	for i, m := range members {
+		stack[i] = m.pr
+	}
+	return stack, nil
+}
+
+// contiguousRun narrows members (already sorted by index) down to the run
+// containing startIndex whose adjacent entries are both index-consecutive and
+// branch-linked, dropping indexed PRs that merely share a topic (e.g. a
+// different stack from the same ghstack user landing on a neighboring index).
+// When the index couldn't be determined (startIndex == -1, e.g. a bare
+// Stack-Topic trailer), every member is kept since there's no ordering signal
+// to split on.
+func contiguousRun(members []indexedPR, startNumber, startIndex int) []indexedPR {
+	if startIndex == -1 {
+		return members
+	}
+
+	start := -1
+	for i, m := range members {
+		if m.pr.Number == startNumber {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return members
+	}
+
+	lo, hi := start, start
+	for lo > 0 && members[lo-1].index == members[lo].index-1 && branchLinked(members[lo-1].pr, members[lo].pr) {
+		lo--
+	}
+	for hi < len(members)-1 && members[hi+1].index == members[hi].index+1 && branchLinked(members[hi].pr, members[hi+1].pr) {
+		hi++
+	}
+	return members[lo : hi+1]
+}
+
+// branchLinked reports whether one PR's branch was cut from the other's, the
+// same base/head relationship findRelatedPRsByBranch checks for the generic
+// branch-chaining fallback.
+func branchLinked(a, b *PullRequest) bool {
+	return a.BaseBranch == b.HeadBranch || b.BaseBranch == a.HeadBranch
+}
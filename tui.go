@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+const tuiRefreshInterval = 30 * time.Second
+
+var (
+	tuiCurrentStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+	tuiClosedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("242"))
+	tuiHelpStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	tuiFilterStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	tuiDiffStyle    = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1).Width(80)
+)
+
+type tuiTickMsg struct{}
+
+type tuiRefreshMsg struct {
+	stack []*PullRequest
+	err   error
+}
+
+type tuiDiffMsg struct {
+	diff string
+	err  error
+}
+
+// tuiModel drives the `stacked-pr tui` view. It reuses the same []*PullRequest
+// graph buildStackGraph already produces, so it consumes the disk cache set up
+// for the regular CLI path and doesn't burn extra API quota on its own.
+type tuiModel struct {
+	visualizer *StackVisualizer
+	owner      string
+	repo       string
+	startPR    int
+	currentPR  int
+
+	stack     []*PullRequest
+	cursor    int
+	filter    string
+	filtering bool
+	diff      string
+	showDiff  bool
+	err       error
+}
+
+func newTUIModel(sv *StackVisualizer, owner, repo string, startPR int) *tuiModel {
+	return &tuiModel{
+		visualizer: sv,
+		owner:      owner,
+		repo:       repo,
+		startPR:    startPR,
+		currentPR:  startPR,
+	}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.refreshCmd(), tuiTickCmd())
+}
+
+func tuiTickCmd() tea.Cmd {
+	return tea.Tick(tuiRefreshInterval, func(time.Time) tea.Msg {
+		return tuiTickMsg{}
+	})
+}
+
+// refreshCmd drops the visualizer's process-lifetime memoization before
+// rebuilding the graph, so a ticker/manual/post-checkout refresh actually
+// falls through to the forge's disk cache (TTL/If-None-Match) instead of
+// replaying the first fetch's snapshot for the life of the TUI session.
+func (m *tuiModel) refreshCmd() tea.Cmd {
+	return func() tea.Msg {
+		m.visualizer.resetCaches()
+		stack, err := m.visualizer.buildStackGraph(m.owner, m.repo, m.startPR)
+		return tuiRefreshMsg{stack: stack, err: err}
+	}
+}
+
+func (m *tuiModel) visiblePRs() []*PullRequest {
+	if m.filter == "" {
+		return m.stack
+	}
+
+	var out []*PullRequest
+	needle := strings.ToLower(m.filter)
+	for _, pr := range m.stack {
+		if strings.Contains(strings.ToLower(pr.Title), needle) {
+			out = append(out, pr)
+		}
+	}
+	return out
+}
+
+func (m *tuiModel) selected() *PullRequest {
+	visible := m.visiblePRs()
+	if m.cursor < 0 || m.cursor >= len(visible) {
+		return nil
+	}
+	return visible[m.cursor]
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tuiTickMsg:
+		return m, tea.Batch(m.refreshCmd(), tuiTickCmd())
+
+	case tuiRefreshMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.stack = msg.stack
+			if m.cursor >= len(m.visiblePRs()) {
+				m.cursor = 0
+			}
+		}
+		return m, nil
+
+	case tuiDiffMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.diff = msg.diff
+			m.showDiff = true
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m *tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filtering {
+		switch msg.String() {
+		case "enter", "esc":
+			m.filtering = false
+		case "backspace":
+			if len(m.filter) > 0 {
+				m.filter = m.filter[:len(m.filter)-1]
+			}
+		default:
+			m.filter += msg.String()
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visiblePRs())-1 {
+			m.cursor++
+		}
+	case "/":
+		m.filtering = true
+	case "esc":
+		m.filter = ""
+		m.showDiff = false
+	case "r":
+		return m, m.refreshCmd()
+	case "enter":
+		return m, m.openInBrowser()
+	case "c":
+		return m, m.checkout()
+	case "d":
+		return m, m.loadDiff()
+	}
+
+	return m, nil
+}
+
+func (m *tuiModel) openInBrowser() tea.Cmd {
+	pr := m.selected()
+	if pr == nil {
+		return nil
+	}
+	number := pr.Number
+	return func() tea.Msg {
+		exec.Command("gh", "pr", "view", strconv.Itoa(number), "--web").Run()
+		return nil
+	}
+}
+
+func (m *tuiModel) checkout() tea.Cmd {
+	pr := m.selected()
+	if pr == nil {
+		return nil
+	}
+	number := pr.Number
+	return func() tea.Msg {
+		if err := exec.Command("gh", "pr", "checkout", strconv.Itoa(number)).Run(); err != nil {
+			return tuiRefreshMsg{err: err}
+		}
+		return m.refreshCmd()()
+	}
+}
+
+func (m *tuiModel) loadDiff() tea.Cmd {
+	pr := m.selected()
+	if pr == nil {
+		return nil
+	}
+	number := pr.Number
+	return func() tea.Msg {
+		out, err := exec.Command("gh", "pr", "diff", strconv.Itoa(number)).Output()
+		return tuiDiffMsg{diff: string(out), err: err}
+	}
+}
+
+func (m *tuiModel) View() string {
+	var list strings.Builder
+
+	for i, pr := range m.visiblePRs() {
+		marker := ""
+		if pr.Number == m.currentPR {
+			marker = " <-"
+		}
+
+		line := fmt.Sprintf("#%d (%s): %s%s", pr.Number, pr.State, pr.Title, marker)
+		if i == m.cursor {
+			line = "> " + line
+		} else {
+			line = "  " + line
+		}
+
+		switch {
+		case pr.Number == m.currentPR:
+			list.WriteString(tuiCurrentStyle.Render(line))
+		case pr.State == "closed":
+			list.WriteString(tuiClosedStyle.Render(line))
+		default:
+			list.WriteString(line)
+		}
+		list.WriteString("\n")
+	}
+
+	if m.err != nil {
+		fmt.Fprintf(&list, "\nerror: %v\n", m.err)
+	}
+
+	if m.filtering {
+		list.WriteString(tuiFilterStyle.Render(fmt.Sprintf("\n/%s", m.filter)) + "\n")
+	} else if m.filter != "" {
+		list.WriteString(tuiFilterStyle.Render(fmt.Sprintf("\nfilter: %s (esc to clear)", m.filter)) + "\n")
+	}
+
+	list.WriteString(tuiHelpStyle.Render("\n↑/↓ move · enter open · c checkout · d diff · r refresh · / filter · q quit"))
+
+	if m.showDiff {
+		return lipgloss.JoinHorizontal(lipgloss.Top, list.String(), tuiDiffStyle.Render(m.diff))
+	}
+
+	return list.String()
+}
+
+// newTUICommand builds the `stacked-pr tui <url>` subcommand. It shares
+// buildStackGraph's disk cache with the root command's non-interactive path.
+func newTUICommand() *cobra.Command {
+	var token, forgeName, apiMode, topicPattern string
+	var noCache bool
+
+	cmd := &cobra.Command{
+		Use:   "tui [PR_URL]",
+		Short: "Interactively navigate and act on a stack",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			forge, err := resolveForge(args[0], forgeName, token, apiMode, noCache, false)
+			if err != nil {
+				return err
+			}
+
+			if _, err := forge.AuthToken(); err != nil {
+				return err
+			}
+
+			ref, err := forge.ParseURL(args[0])
+			if err != nil {
+				return err
+			}
+
+			visualizer, err := NewStackVisualizer(forge, ref.Host, topicPattern)
+			if err != nil {
+				return err
+			}
+
+			model := newTUIModel(visualizer, ref.Owner, ref.Repo, ref.Number)
+			_, err = tea.NewProgram(model).Run()
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&token, "token", "t", "", "access token for the target forge")
+	cmd.Flags().StringVar(&forgeName, "forge", "", `forge backend to use: "github", "gitlab", or "gitea" (autodetected from the URL host when omitted)`)
+	cmd.Flags().StringVar(&apiMode, "api", "graphql", `GitHub API to use: "rest" or "graphql" (ignored for other forges)`)
+	cmd.Flags().StringVar(&topicPattern, "topic-pattern", "", "custom regex for detecting stacked-diff head branches")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "disable the on-disk PR cache entirely")
+
+	return cmd
+}